@@ -296,8 +296,15 @@ func NewApp(client *Client) *cli.App {
 							Action: client.CreateOCRKeyBundle,
 						},
 						{
-							Name:   "delete",
-							Usage:  format(`Deletes the encrypted OCR key bundle matching the given ID`),
+							Name: "delete",
+							Usage: format(`Archives the encrypted OCR key bundle matching the given ID,
+               or permanently removes it with --hard`),
+							Flags: []cli.Flag{
+								cli.BoolFlag{
+									Name:  "hard",
+									Usage: "permanently remove the key bundle, even if it is already archived",
+								},
+							},
 							Action: client.DeleteOCRKeyBundle,
 						},
 						{
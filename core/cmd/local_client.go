@@ -25,6 +25,8 @@ import (
 	strpkg "github.com/smartcontractkit/chainlink/core/store"
 	"github.com/smartcontractkit/chainlink/core/store/migrations"
 	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/store/models/ocrkey"
+	"github.com/smartcontractkit/chainlink/core/store/models/p2pkey"
 	"github.com/smartcontractkit/chainlink/core/store/orm"
 	"github.com/smartcontractkit/chainlink/core/store/presenters"
 	"github.com/smartcontractkit/chainlink/core/utils"
@@ -55,6 +57,12 @@ func (cli *Client) RunNode(c *clipkg.Context) error {
 		return cli.errorOut(fmt.Errorf("error initializing SGX enclave: %+v", err))
 	}
 
+	if cli.Config.KeystoreSelfTest() {
+		if err = keystoreSelfTest(); err != nil {
+			return cli.errorOut(err)
+		}
+	}
+
 	app := cli.AppFactory.NewApplication(cli.Config, func(app chainlink.Application) {
 		store := app.GetStore()
 		logIfNonceOutOfSync(store)
@@ -189,6 +197,17 @@ func passwordFromFile(pwdFile string) (string, error) {
 	dat, err := ioutil.ReadFile(pwdFile)
 	return strings.TrimSpace(string(dat)), err
 }
+
+// keystoreSelfTest checks that P2P keys and OCR key bundles survive an
+// encryption round trip before the node touches any real keys
+func keystoreSelfTest() error {
+	logger.Info("Running keystore self test")
+	if err := p2pkey.SelfTest(); err != nil {
+		return err
+	}
+	return ocrkey.SelfTest()
+}
+
 func logIfNonceOutOfSync(store *strpkg.Store) {
 	account := store.TxManager.NextActiveAccount()
 	if account == nil {
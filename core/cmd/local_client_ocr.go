@@ -84,6 +84,7 @@ Encrypted Off-Chain Reporting Key Bundles in DB
 		fmt.Println("ID                ", key.ID)
 		fmt.Println("On-chain Address  ", "0x"+hex.EncodeToString(key.OnChainSigningAddress[:]))
 		fmt.Println("Off-chain PubKey  ", hex.EncodeToString(key.OffChainPublicKey))
+		fmt.Println("Label             ", key.Label.ValueOrZero())
 		if keyidx != len(keys)-1 {
 			fmt.Println(
 				"-----------------------------------------------------------------------------------")
@@ -107,18 +108,35 @@ func (cli *Client) deleteOCRKeyBundle(c *clipkg.Context) error {
 	cli.Config.Dialect = orm.DialectPostgresWithoutLock
 	store := cli.AppFactory.NewApplication(cli.Config).GetStore()
 
-	key, err := store.FindEncryptedOCRKeyBundleByID(id)
+	hard := c.Bool("hard")
+	var key *ocrkey.EncryptedKeyBundle
+	var err error
+	if hard {
+		// archived bundles can still be purged, so include them in the lookup
+		key, err = store.Unscoped().FindEncryptedOCRKeyBundleByID(id)
+	} else {
+		key, err = store.FindEncryptedOCRKeyBundleByID(id)
+	}
 	if gorm.IsRecordNotFoundError(err) {
 		return errors.New("Unable to find the OCR key bundle with the provided ID")
 	} else if err != nil {
 		return errors.Wrapf(err, "while fetching the OCR key bundle")
 	}
 
+	if hard {
+		err = store.PurgeEncryptedOCRKeyBundle(key)
+		if err != nil {
+			return errors.Wrapf(err, "while purging the OCR key bundle")
+		}
+		fmt.Printf("Successfully purged OCR key bundle %s\n", key.ID)
+		return nil
+	}
+
 	err = store.DeleteEncryptedOCRKeyBundle(key)
 	if err != nil {
 		return errors.Wrapf(err, "while deleting the OCR key bundle")
 	}
 
-	fmt.Printf("Successfully deleted OCR key bundle %s\n", key.ID)
+	fmt.Printf("Successfully archived OCR key bundle %s. Use --hard to remove it permanently\n", key.ID)
 	return nil
 }
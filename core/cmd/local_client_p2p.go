@@ -83,6 +83,7 @@ Encrypted P2P keys in DB
 		fmt.Println("ID                ", key.ID)
 		fmt.Println("PeerID            ", key.PeerID)
 		fmt.Println("Public Key        ", hex.EncodeToString(key.PubKey))
+		fmt.Println("Label             ", key.Label.ValueOrZero())
 		if keyidx != len(keys)-1 {
 			fmt.Println(
 				"-----------------------------------------------------------------------------------")
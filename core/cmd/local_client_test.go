@@ -821,3 +821,47 @@ func TestClient_DeleteOCRKeyBundle(t *testing.T) {
 	require.NoError(t, err)
 	require.Len(t, keys, 0)
 }
+
+func TestClient_DeleteOCRKeyBundle_Hard(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	app := new(mocks.Application)
+	app.On("GetStore").Return(store)
+
+	auth := cltest.CallbackAuthenticator{}
+	apiPrompt := &cltest.MockAPIInitializer{}
+	client := cmd.Client{
+		Config:                 store.Config,
+		AppFactory:             cltest.InstanceAppFactory{App: app},
+		KeyStoreAuthenticator:  auth,
+		FallbackAPIInitializer: apiPrompt,
+		Runner:                 cltest.EmptyRunner{},
+	}
+
+	live := cltest.MustInsertOffchainreportingKeyBundle(t, store)
+	archived := cltest.MustInsertOffchainreportingKeyBundle(t, store)
+
+	set := flag.NewFlagSet("test", 0)
+	set.Parse([]string{archived.ID})
+	require.NoError(t, client.DeleteOCRKeyBundle(cli.NewContext(nil, set, nil)))
+	deleted, err := store.FindDeletedOCRKeyBundles()
+	require.NoError(t, err)
+	require.Len(t, deleted, 1)
+
+	// purging works on archived bundles as well as live ones
+	for _, id := range []string{archived.ID, live.ID} {
+		set = flag.NewFlagSet("test", 0)
+		set.Bool("hard", true, "")
+		set.Parse([]string{id})
+		require.NoError(t, client.DeleteOCRKeyBundle(cli.NewContext(nil, set, nil)))
+	}
+
+	keys, err := store.FindEncryptedOCRKeyBundles()
+	require.NoError(t, err)
+	require.Len(t, keys, 0)
+	deleted, err = store.FindDeletedOCRKeyBundles()
+	require.NoError(t, err)
+	require.Len(t, deleted, 0)
+}
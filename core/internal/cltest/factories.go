@@ -20,6 +20,8 @@ import (
 	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor"
 	strpkg "github.com/smartcontractkit/chainlink/core/store"
 	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/store/models/ocrkey"
+	"github.com/smartcontractkit/chainlink/core/store/models/p2pkey"
 	"github.com/smartcontractkit/chainlink/core/utils"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -809,3 +811,25 @@ func MustInsertOffchainreportingOracleSpec(t *testing.T, store *strpkg.Store) mo
 	require.NoError(t, store.DB.Create(&spec).Error)
 	return spec
 }
+
+func MustInsertOffchainreportingKeyBundle(t *testing.T, store *strpkg.Store) *ocrkey.EncryptedKeyBundle {
+	t.Helper()
+
+	key, err := ocrkey.NewKeyBundle()
+	require.NoError(t, err)
+	encryptedKey, err := key.Encrypt(Password, utils.FastScryptParams)
+	require.NoError(t, err)
+	require.NoError(t, store.CreateEncryptedOCRKeyBundle(encryptedKey))
+	return encryptedKey
+}
+
+func MustInsertP2PKey(t *testing.T, store *strpkg.Store) p2pkey.EncryptedP2PKey {
+	t.Helper()
+
+	key, err := p2pkey.CreateKey()
+	require.NoError(t, err)
+	encryptedKey, err := key.ToEncryptedP2PKey(Password, utils.FastScryptParams)
+	require.NoError(t, err)
+	require.NoError(t, store.UpsertEncryptedP2PKey(&encryptedKey))
+	return encryptedKey
+}
@@ -73,6 +73,10 @@ import (
 	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1601294261"
 	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1601459029"
 	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1602180905"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1602695741"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1602775413"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1602863292"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1602950130"
 
 	"github.com/jinzhu/gorm"
 	"github.com/pkg/errors"
@@ -365,6 +369,26 @@ func init() {
 			Migrate:  migration1602180905.Migrate,
 			Rollback: migration1602180905.Rollback,
 		},
+		{
+			ID:       "1602695741",
+			Migrate:  migration1602695741.Migrate,
+			Rollback: migration1602695741.Rollback,
+		},
+		{
+			ID:       "1602775413",
+			Migrate:  migration1602775413.Migrate,
+			Rollback: migration1602775413.Rollback,
+		},
+		{
+			ID:       "1602863292",
+			Migrate:  migration1602863292.Migrate,
+			Rollback: migration1602863292.Rollback,
+		},
+		{
+			ID:       "1602950130",
+			Migrate:  migration1602950130.Migrate,
+			Rollback: migration1602950130.Rollback,
+		},
 	}
 }
 
@@ -236,6 +236,42 @@ func TestMigrate_Migration1586369235(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestMigrate_Migration1602950130(t *testing.T) {
+	_, orm, cleanup := cltest.BootstrapThrowawayORM(t, "migrations", false)
+	defer cleanup()
+
+	err := orm.RawDB(func(db *gorm.DB) error {
+		require.NoError(t, migrations.MigrateTo(db, "1602863292"))
+
+		insert := `INSERT INTO encrypted_p2p_keys (id, peer_id, pub_key, encrypted_priv_key, created_at, updated_at, label) VALUES (?, ?, ?, '{}', NOW(), NOW(), ?)`
+		require.NoError(t, db.Exec(insert, 1, "a", []byte{1}, "mainnet").Error)
+		require.NoError(t, db.Exec(insert, 2, "b", []byte{2}, "mainnet").Error)
+		require.NoError(t, db.Exec(insert, 3, "c", []byte{3}, "mainnet-2").Error)
+		require.NoError(t, db.Exec(insert, 4, "d", []byte{4}, "mainnet").Error)
+		require.NoError(t, db.Exec(insert, 5, "e", []byte{5}, "testnet").Error)
+		require.NoError(t, db.Exec(insert, 6, "f", []byte{6}, nil).Error)
+		require.NoError(t, db.Exec(insert, 7, "g", []byte{7}, nil).Error)
+
+		require.NoError(t, migrations.MigrateTo(db, "1602950130"))
+
+		var labels []struct {
+			ID    int32
+			Label *string
+		}
+		require.NoError(t, db.Raw(`SELECT id, label FROM encrypted_p2p_keys ORDER BY id`).Scan(&labels).Error)
+		require.Len(t, labels, 7)
+		assert.Equal(t, "mainnet", *labels[0].Label)
+		assert.Equal(t, "mainnet-3", *labels[1].Label)
+		assert.Equal(t, "mainnet-2", *labels[2].Label)
+		assert.Equal(t, "mainnet-4", *labels[3].Label)
+		assert.Equal(t, "testnet", *labels[4].Label)
+		assert.Nil(t, labels[5].Label)
+		assert.Nil(t, labels[6].Label)
+		return nil
+	})
+	require.NoError(t, err)
+}
+
 func TestMigrate_NewerVersionGuard(t *testing.T) {
 	_, orm, cleanup := cltest.BootstrapThrowawayORM(t, "migrations", false)
 	defer cleanup()
@@ -0,0 +1,28 @@
+package migration1602695741
+
+import (
+	"github.com/jinzhu/gorm"
+)
+
+const up = `
+ALTER TABLE encrypted_ocr_key_bundles ADD COLUMN deleted_at timestamptz;
+CREATE INDEX idx_encrypted_ocr_key_bundles_deleted_at ON encrypted_ocr_key_bundles (deleted_at);
+`
+
+const down = `
+DELETE FROM encrypted_ocr_key_bundles WHERE deleted_at IS NOT NULL;
+DROP INDEX idx_encrypted_ocr_key_bundles_deleted_at;
+ALTER TABLE encrypted_ocr_key_bundles DROP COLUMN deleted_at;
+`
+
+// Migrate adds soft delete support to encrypted_ocr_key_bundles
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(up).Error
+}
+
+// Rollback removes soft delete support from encrypted_ocr_key_bundles. Soft
+// deleted bundles are removed for good, so that they do not come back as live
+// bundles once the column is gone.
+func Rollback(tx *gorm.DB) error {
+	return tx.Exec(down).Error
+}
@@ -0,0 +1,25 @@
+package migration1602775413
+
+import (
+	"github.com/jinzhu/gorm"
+)
+
+const up = `
+ALTER TABLE encrypted_p2p_keys ADD COLUMN label text;
+ALTER TABLE encrypted_ocr_key_bundles ADD COLUMN label text;
+`
+
+const down = `
+ALTER TABLE encrypted_p2p_keys DROP COLUMN label;
+ALTER TABLE encrypted_ocr_key_bundles DROP COLUMN label;
+`
+
+// Migrate adds an optional label to encrypted P2P keys and OCR key bundles
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(up).Error
+}
+
+// Rollback removes the labels from encrypted P2P keys and OCR key bundles
+func Rollback(tx *gorm.DB) error {
+	return tx.Exec(down).Error
+}
@@ -0,0 +1,23 @@
+package migration1602863292
+
+import (
+	"github.com/jinzhu/gorm"
+)
+
+const up = `
+CREATE INDEX idx_encrypted_ocr_key_bundles_label ON encrypted_ocr_key_bundles (label);
+`
+
+const down = `
+DROP INDEX idx_encrypted_ocr_key_bundles_label;
+`
+
+// Migrate indexes encrypted OCR key bundles by label
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(up).Error
+}
+
+// Rollback drops the encrypted OCR key bundle label index
+func Rollback(tx *gorm.DB) error {
+	return tx.Exec(down).Error
+}
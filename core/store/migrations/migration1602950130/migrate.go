@@ -0,0 +1,46 @@
+package migration1602950130
+
+import (
+	"github.com/jinzhu/gorm"
+)
+
+const up = `
+DO $$
+DECLARE
+	dup RECORD;
+	n INTEGER;
+BEGIN
+	FOR dup IN
+		SELECT id, label FROM encrypted_p2p_keys k
+		WHERE label IS NOT NULL AND EXISTS (
+			SELECT 1 FROM encrypted_p2p_keys o WHERE o.label = k.label AND o.id < k.id
+		)
+		ORDER BY id
+	LOOP
+		n := 2;
+		WHILE EXISTS (SELECT 1 FROM encrypted_p2p_keys WHERE label = dup.label || '-' || n) LOOP
+			n := n + 1;
+		END LOOP;
+		UPDATE encrypted_p2p_keys SET label = dup.label || '-' || n WHERE id = dup.id;
+	END LOOP;
+END
+$$;
+CREATE UNIQUE INDEX idx_unique_p2p_key_labels ON encrypted_p2p_keys (label);
+`
+
+const down = `
+DROP INDEX idx_unique_p2p_key_labels;
+`
+
+// Migrate makes encrypted P2P key labels unique. Unlabelled keys are NULL and
+// so do not conflict with each other. Where several keys already share a
+// label, the oldest keeps it and each of the others gets the first "-N"
+// suffix, from 2 up, that no key is using yet.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(up).Error
+}
+
+// Rollback drops the uniqueness constraint on encrypted P2P key labels
+func Rollback(tx *gorm.DB) error {
+	return tx.Exec(down).Error
+}
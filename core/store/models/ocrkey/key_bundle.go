@@ -1,12 +1,14 @@
 package ocrkey
 
 import (
+	"bytes"
 	"crypto/ecdsa"
 	"crypto/ed25519"
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io"
 	"log"
 	"math/big"
 	"time"
@@ -16,7 +18,12 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/crypto/secp256k1"
 	"github.com/pkg/errors"
+	"github.com/tyler-smith/go-bip39"
 	"golang.org/x/crypto/curve25519"
+	"golang.org/x/crypto/hkdf"
+	"gopkg.in/guregu/null.v3"
+
+	"github.com/smartcontractkit/chainlink/core/utils"
 )
 
 // KeyBundle represents the bundle of keys needed for OCR
@@ -33,8 +40,10 @@ type EncryptedKeyBundle struct {
 	OnChainSigningAddress OnChainSigningAddress
 	OffChainPublicKey     OffChainPublicKey
 	EncryptedPrivateKeys  []byte
+	Label                 null.String
 	CreatedAt             time.Time
 	UpdatedAt             time.Time
+	DeletedAt             null.Time
 }
 
 type keyBundleRawData struct {
@@ -43,11 +52,6 @@ type keyBundleRawData struct {
 	OffChainEncryption [curve25519.ScalarSize]byte
 }
 
-type scryptParams struct{ N, P int }
-
-var defaultScryptParams = scryptParams{
-	N: keystore.StandardScryptN, P: keystore.StandardScryptP}
-
 var curve = secp256k1.S256()
 
 func (EncryptedKeyBundle) TableName() string {
@@ -73,6 +77,55 @@ func NewKeyBundle() (*KeyBundle, error) {
 	if err != nil {
 		return nil, err
 	}
+	return newKeyBundle(onChainPriv, offChainPriv, encryptionPriv)
+}
+
+// mnemonicDerivationInfo domain-separates the HKDF stream used to derive OCR
+// key bundles from mnemonics. Changing it changes every derived bundle.
+const mnemonicDerivationInfo = "chainlink ocr key bundle v1"
+
+// NewKeyBundleFromMnemonic deterministically derives a set of OCR keys from a
+// BIP-39 mnemonic, so that the same mnemonic always reproduces the same bundle
+// and bundle ID
+func NewKeyBundleFromMnemonic(mnemonic string) (*KeyBundle, error) {
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, "")
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid mnemonic")
+	}
+	reader := hkdf.New(sha256.New, seed, nil, []byte(mnemonicDerivationInfo))
+
+	// ecdsa.GenerateKey may consume a variable number of bytes from its
+	// reader, so the scalar is derived directly, as in FIPS 186-4 B.4.1
+	params := curve.Params()
+	b := make([]byte, params.BitSize/8+8)
+	if _, err = io.ReadFull(reader, b); err != nil {
+		return nil, err
+	}
+	d := new(big.Int).SetBytes(b)
+	d.Mod(d, new(big.Int).Sub(params.N, big.NewInt(1)))
+	d.Add(d, big.NewInt(1))
+	onChainPriv := &onChainPrivateKey{PublicKey: ecdsa.PublicKey{Curve: curve}, D: d}
+	onChainPriv.PublicKey.X, onChainPriv.PublicKey.Y = curve.ScalarBaseMult(d.Bytes())
+
+	offChainSeed := make([]byte, ed25519.SeedSize)
+	if _, err = io.ReadFull(reader, offChainSeed); err != nil {
+		return nil, err
+	}
+	offChainPriv := ed25519.NewKeyFromSeed(offChainSeed)
+
+	var encryptionPriv [curve25519.ScalarSize]byte
+	if _, err = io.ReadFull(reader, encryptionPriv[:]); err != nil {
+		return nil, err
+	}
+	return newKeyBundle(onChainPriv, offChainPriv, encryptionPriv)
+}
+
+// newKeyBundle assembles a KeyBundle from its private keys and derives its ID
+func newKeyBundle(
+	onChainPriv *onChainPrivateKey,
+	offChainPriv ed25519.PrivateKey,
+	encryptionPriv [curve25519.ScalarSize]byte,
+) (*KeyBundle, error) {
 	k := &KeyBundle{
 		onChainSigning:     onChainPriv,
 		offChainSigning:    (*offChainPrivateKey)(&offChainPriv),
@@ -134,16 +187,21 @@ func (pk *KeyBundle) PublicKeyConfig() [curve25519.PointSize]byte {
 }
 
 // Encrypt combines the KeyBundle into a single json-serialized
-// bytes array and then encrypts
-func (pk *KeyBundle) Encrypt(auth string) (*EncryptedKeyBundle, error) {
-	return pk.encrypt(auth, defaultScryptParams)
+// bytes array and then encrypts, using the default scrypt params
+// unless a set of params is given
+func (pk *KeyBundle) Encrypt(auth string, p ...utils.ScryptParams) (*EncryptedKeyBundle, error) {
+	scryptParams, err := utils.GetScryptParams(p...)
+	if err != nil {
+		return nil, err
+	}
+	return pk.encrypt(auth, scryptParams)
 }
 
 // encrypt combines the KeyBundle into a single json-serialized
 // bytes array and then encrypts, using the provided scrypt params
 // separated into a different function so that scryptParams can be
 // weakened in tests
-func (pk *KeyBundle) encrypt(auth string, scryptParams scryptParams) (*EncryptedKeyBundle, error) {
+func (pk *KeyBundle) encrypt(auth string, scryptParams utils.ScryptParams) (*EncryptedKeyBundle, error) {
 	marshalledPrivK, err := json.Marshal(&pk)
 	if err != nil {
 		return nil, err
@@ -189,6 +247,38 @@ func (encKey *EncryptedKeyBundle) Decrypt(auth string) (*KeyBundle, error) {
 	return &pk, nil
 }
 
+// SelfTest generates a throwaway key bundle, encrypts it under a random
+// password and checks that it decrypts back to the same keys, without
+// touching the DB. It surfaces a broken crypto environment before any real
+// key is at risk.
+func SelfTest(p ...utils.ScryptParams) error {
+	pk, err := NewKeyBundle()
+	if err != nil {
+		return errors.Wrap(err, "OCR key bundle self test: could not create key bundle")
+	}
+	password := utils.NewSecret(32)
+	encrypted, err := pk.Encrypt(password, p...)
+	if err != nil {
+		return errors.Wrap(err, "OCR key bundle self test: could not encrypt key bundle")
+	}
+	decrypted, err := encrypted.Decrypt(password)
+	if err != nil {
+		return errors.Wrap(err, "OCR key bundle self test: could not decrypt key bundle")
+	}
+	original, err := json.Marshal(pk)
+	if err != nil {
+		return errors.Wrap(err, "OCR key bundle self test: could not marshal key bundle")
+	}
+	roundTripped, err := json.Marshal(decrypted)
+	if err != nil {
+		return errors.Wrap(err, "OCR key bundle self test: could not marshal key bundle")
+	}
+	if !bytes.Equal(original, roundTripped) {
+		return errors.New("OCR key bundle self test: decrypted key bundle does not match the original")
+	}
+	return nil
+}
+
 // MarshalJSON marshals the private keys into json
 func (pk *KeyBundle) MarshalJSON() ([]byte, error) {
 	rawKeyData := keyBundleRawData{
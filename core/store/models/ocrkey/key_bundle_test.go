@@ -3,11 +3,13 @@ package ocrkey
 import (
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/smartcontractkit/chainlink/core/utils"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
-var fastScryptParamsXXXTestingOnly = scryptParams{N: 2, P: 1}
+var fastScryptParamsXXXTestingOnly = utils.ScryptParams{N: 2, P: 1}
 
 func assertKeyBundlesEqual(t *testing.T, pk1 *KeyBundle, pk2 *KeyBundle) {
 	assert.Equal(t, pk1.ID, pk2.ID)
@@ -57,3 +59,58 @@ func TestOCRKeys_Encrypt_Decrypt(t *testing.T) {
 	require.NoError(t, err)
 	assertKeyBundlesEqual(t, pk, pkDecrypted)
 }
+
+func TestOCRKeys_NewKeyBundleFromMnemonic(t *testing.T) {
+	t.Parallel()
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	pk1, err := NewKeyBundleFromMnemonic(mnemonic)
+	require.NoError(t, err)
+	pk2, err := NewKeyBundleFromMnemonic(mnemonic)
+	require.NoError(t, err)
+	assertKeyBundlesEqual(t, pk1, pk2)
+	assert.Equal(t, "ec2c63b5ecef3c88db11595fc9d6b3a2ec18ea1226e4680b6e380f4be6d5f411", pk1.ID)
+	assert.Equal(t, "0x6daD6C84b8f4c6390cDB574E5EBfeC68C1fE5161", common.Address(pk1.PublicKeyAddressOnChain()).Hex())
+
+	other, err := NewKeyBundleFromMnemonic("legal winner thank year wave sausage worth useful legal winner thank yellow")
+	require.NoError(t, err)
+	assertKeyBundlesNotEqual(t, pk1, other)
+
+	// check that the derived keys are usable
+	pkEncrypted, err := pk1.encrypt("password", fastScryptParamsXXXTestingOnly)
+	require.NoError(t, err)
+	pkDecrypted, err := pkEncrypted.Decrypt("password")
+	require.NoError(t, err)
+	assertKeyBundlesEqual(t, pk1, pkDecrypted)
+	_, err = pk1.SignOnChain([]byte("message"))
+	require.NoError(t, err)
+}
+
+func TestOCRKeys_NewKeyBundleFromMnemonic_Invalid(t *testing.T) {
+	t.Parallel()
+	// last word breaks the checksum
+	_, err := NewKeyBundleFromMnemonic("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon")
+	assert.Error(t, err)
+	_, err = NewKeyBundleFromMnemonic("not a mnemonic")
+	assert.Error(t, err)
+}
+
+func TestOCRKeys_Encrypt_ScryptParams(t *testing.T) {
+	t.Parallel()
+	pk, err := NewKeyBundle()
+	require.NoError(t, err)
+
+	pkEncrypted, err := pk.Encrypt("password", utils.ScryptParams{N: 1 << 13, P: 1})
+	require.NoError(t, err)
+	pkDecrypted, err := pkEncrypted.Decrypt("password")
+	require.NoError(t, err)
+	assertKeyBundlesEqual(t, pk, pkDecrypted)
+
+	_, err = pk.Encrypt("password", utils.ScryptParams{N: 2, P: 1})
+	assert.Error(t, err)
+}
+
+func TestOCRKeys_SelfTest(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, SelfTest(utils.FastScryptParams))
+}
@@ -1,6 +1,7 @@
 package p2pkey
 
 import (
+	"crypto/ed25519"
 	"crypto/rand"
 	"encoding/json"
 	"time"
@@ -9,6 +10,9 @@ import (
 	cryptop2p "github.com/libp2p/go-libp2p-core/crypto"
 	peer "github.com/libp2p/go-libp2p-core/peer"
 	"github.com/pkg/errors"
+	"gopkg.in/guregu/null.v3"
+
+	"github.com/smartcontractkit/chainlink/core/utils"
 )
 
 // Key represents a libp2p private key
@@ -29,6 +33,7 @@ type EncryptedP2PKey struct {
 	PeerID           string
 	PubKey           []byte
 	EncryptedPrivKey []byte
+	Label            null.String
 	CreatedAt        time.Time
 	UpdatedAt        time.Time
 }
@@ -48,10 +53,20 @@ func CreateKey() (Key, error) {
 	}, nil
 }
 
-type ScryptParams struct{ N, P int }
-
-var defaultScryptParams = ScryptParams{
-	N: keystore.StandardScryptN, P: keystore.StandardScryptP}
+// CreateKeyFromSeed deterministically derives a libp2p keypair from seed, so
+// that a backed-up seed always reproduces the same peer ID
+func CreateKeyFromSeed(seed []byte) (Key, error) {
+	if len(seed) != ed25519.SeedSize {
+		return Key{}, errors.Errorf("p2p key seed must be %d bytes, got %d", ed25519.SeedSize, len(seed))
+	}
+	p2pPrivkey, err := cryptop2p.UnmarshalEd25519PrivateKey(ed25519.NewKeyFromSeed(seed))
+	if err != nil {
+		return Key{}, errors.Wrap(err, "could not derive p2p key from seed")
+	}
+	return Key{
+		p2pPrivkey,
+	}, nil
+}
 
 // type is added to the beginning of the passwords for
 // P2P key, so that the keys can't accidentally be mis-used
@@ -61,8 +76,13 @@ func adulteratedPassword(auth string) string {
 	return s
 }
 
-func (k Key) ToEncryptedP2PKey(auth string) (s EncryptedP2PKey, err error) {
-	scryptParams := defaultScryptParams
+// ToEncryptedP2PKey encrypts k under auth, using the default scrypt params
+// unless a set of params is given
+func (k Key) ToEncryptedP2PKey(auth string, p ...utils.ScryptParams) (s EncryptedP2PKey, err error) {
+	scryptParams, err := utils.GetScryptParams(p...)
+	if err != nil {
+		return s, err
+	}
 	var marshalledPrivK []byte
 	marshalledPrivK, err = cryptop2p.MarshalPrivateKey(k)
 	if err != nil {
@@ -112,3 +132,26 @@ func (e EncryptedP2PKey) Decrypt(auth string) (k Key, err error) {
 		privK,
 	}, nil
 }
+
+// SelfTest generates a throwaway key, encrypts it under a random password and
+// checks that it decrypts back to the same key, without touching the DB. It
+// surfaces a broken crypto environment before any real key is at risk.
+func SelfTest(p ...utils.ScryptParams) error {
+	k, err := CreateKey()
+	if err != nil {
+		return errors.Wrap(err, "p2p key self test: could not create key")
+	}
+	password := utils.NewSecret(32)
+	encrypted, err := k.ToEncryptedP2PKey(password, p...)
+	if err != nil {
+		return errors.Wrap(err, "p2p key self test: could not encrypt key")
+	}
+	decrypted, err := encrypted.Decrypt(password)
+	if err != nil {
+		return errors.Wrap(err, "p2p key self test: could not decrypt key")
+	}
+	if !k.Equals(decrypted) {
+		return errors.New("p2p key self test: decrypted key does not match the original")
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package p2pkey
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestP2PKeys_CreateKeyFromSeed(t *testing.T) {
+	t.Parallel()
+
+	seed := bytes.Repeat([]byte{0x42}, 32)
+	k1, err := CreateKeyFromSeed(seed)
+	require.NoError(t, err)
+	k2, err := CreateKeyFromSeed(seed)
+	require.NoError(t, err)
+
+	peerID1, err := k1.GetPeerID()
+	require.NoError(t, err)
+	peerID2, err := k2.GetPeerID()
+	require.NoError(t, err)
+	assert.Equal(t, peerID1, peerID2)
+	assert.Equal(t, "12D3KooWC4T1AXU2s2YBgGJ2FeaYVtsKoHZWJeubnWe9SnuSE7Zb", peerID1.Pretty())
+	assert.True(t, k1.Equals(k2))
+
+	other, err := CreateKeyFromSeed(bytes.Repeat([]byte{0x43}, 32))
+	require.NoError(t, err)
+	otherPeerID, err := other.GetPeerID()
+	require.NoError(t, err)
+	assert.NotEqual(t, peerID1, otherPeerID)
+}
+
+func TestP2PKeys_CreateKeyFromSeed_InvalidLength(t *testing.T) {
+	t.Parallel()
+
+	_, err := CreateKeyFromSeed(make([]byte, 16))
+	assert.EqualError(t, err, "p2p key seed must be 32 bytes, got 16")
+}
+
+func TestP2PKeys_ToEncryptedP2PKey_ScryptParams(t *testing.T) {
+	t.Parallel()
+	k, err := CreateKey()
+	require.NoError(t, err)
+
+	encrypted, err := k.ToEncryptedP2PKey("password", utils.ScryptParams{N: 1 << 13, P: 1})
+	require.NoError(t, err)
+	decrypted, err := encrypted.Decrypt("password")
+	require.NoError(t, err)
+	assert.True(t, k.Equals(decrypted))
+
+	_, err = k.ToEncryptedP2PKey("password", utils.ScryptParams{N: 2, P: 1})
+	assert.Error(t, err)
+}
+
+func TestP2PKeys_SelfTest(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, SelfTest(utils.FastScryptParams))
+}
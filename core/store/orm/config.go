@@ -391,6 +391,15 @@ func (c Config) JSONConsole() bool {
 	return c.viper.GetBool(EnvVarName("JSONConsole"))
 }
 
+// KeystoreSelfTest makes the node round-trip a throwaway P2P key and OCR key
+// bundle through encryption on startup, failing to start if either does not
+// decrypt back. Each round trip runs scrypt at full strength, which adds a
+// few seconds and a few hundred MB of memory to startup, so it is off by
+// default.
+func (c Config) KeystoreSelfTest() bool {
+	return c.viper.GetBool(EnvVarName("KeystoreSelfTest"))
+}
+
 // LinkContractAddress represents the address
 func (c Config) LinkContractAddress() string {
 	return c.viper.GetString(EnvVarName("LinkContractAddress"))
@@ -25,12 +25,14 @@ import (
 	"github.com/smartcontractkit/chainlink/core/store/models/vrfkey"
 	"github.com/smartcontractkit/chainlink/core/utils"
 
+	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/jinzhu/gorm"
 	_ "github.com/jinzhu/gorm/dialects/postgres" // http://doc.gorm.io/database.html#connecting-to-a-database
 	"github.com/pkg/errors"
 	uuid "github.com/satori/go.uuid"
 	"go.uber.org/multierr"
+	"gopkg.in/guregu/null.v3"
 )
 
 var (
@@ -40,6 +42,18 @@ var (
 	ErrNoAdvisoryLock = errors.New("can't acquire advisory lock")
 	// ErrReleaseLockFailed  is returned when releasing the advisory lock fails.
 	ErrReleaseLockFailed = errors.New("advisory lock release failed")
+	// ErrWrongPassword is returned when a key fails to decrypt with the given
+	// password.
+	ErrWrongPassword = errors.New("wrong password")
+	// ErrNoKeysToVerify is returned when verifying a password without any
+	// encrypted P2P keys or OCR key bundles to check it against.
+	ErrNoKeysToVerify = errors.New("no keys to verify the password against")
+	// ErrWrongConfirmation is returned when a destructive operation is not
+	// confirmed with the expected token.
+	ErrWrongConfirmation = errors.New("wrong confirmation token")
+	// ErrLabelTaken is returned when a P2P key label is already used by
+	// another key.
+	ErrLabelTaken = errors.New("label is already taken by another key")
 	// ErrOptimisticUpdateConflict is returned when a record update failed
 	// because another update occurred while the model was in memory and the
 	// differences must be reconciled.
@@ -1304,8 +1318,201 @@ func (orm *ORM) FindEncryptedSecretVRFKeys(where ...vrfkey.EncryptedVRFKey) (
 	return retrieved, orm.DB.Find(&retrieved, anonWhere...).Error
 }
 
+// UpsertEncryptedP2PKey creates the encrypted P2P key, or updates the
+// encrypted private key of the existing key with the same public key.
+// ErrLabelTaken is returned if another key already has k's label.
 func (orm *ORM) UpsertEncryptedP2PKey(k *p2pkey.EncryptedP2PKey) error {
-	return orm.DB.Set("gorm:insert_option", "ON CONFLICT (pub_key) DO UPDATE SET encrypted_priv_key=EXCLUDED.encrypted_priv_key, updated_at=NOW()").Create(k).Error
+	return upsertEncryptedP2PKey(orm.DB, k)
+}
+
+func upsertEncryptedP2PKey(tx *gorm.DB, k *p2pkey.EncryptedP2PKey) error {
+	err := tx.Set("gorm:insert_option", "ON CONFLICT (pub_key) DO UPDATE SET encrypted_priv_key=EXCLUDED.encrypted_priv_key, updated_at=NOW()").Create(k).Error
+	return mapP2PKeyLabelError(err)
+}
+
+// VerifyP2PAndOCRKeysPassword checks password against a single encrypted key,
+// preferring an OCR key bundle over a P2P key, without persisting anything.
+// It returns ErrWrongPassword if the key does not decrypt, or
+// ErrNoKeysToVerify if there are no keys at all.
+func (orm *ORM) VerifyP2PAndOCRKeysPassword(password string) error {
+	err := orm.decryptAnyP2POrOCRKey(password)
+	if errors.Cause(err) == keystore.ErrDecrypt {
+		return ErrWrongPassword
+	}
+	return err
+}
+
+func (orm *ORM) decryptAnyP2POrOCRKey(password string) error {
+	var ocrKey ocrkey.EncryptedKeyBundle
+	err := orm.DB.First(&ocrKey).Error
+	if err == nil {
+		_, err = ocrKey.Decrypt(password)
+		return err
+	} else if !gorm.IsRecordNotFoundError(err) {
+		return err
+	}
+
+	var p2pKey p2pkey.EncryptedP2PKey
+	err = orm.DB.First(&p2pKey).Error
+	if err == nil {
+		_, err = p2pKey.Decrypt(password)
+		return err
+	} else if gorm.IsRecordNotFoundError(err) {
+		return ErrNoKeysToVerify
+	}
+	return err
+}
+
+// RotationPlan holds every encrypted P2P key and OCR key bundle re-encrypted
+// under a new password by PrepareChangePassword, ready to be saved by
+// CommitChangePassword
+type RotationPlan struct {
+	p2pKeys       []rotatedKey
+	ocrKeyBundles []rotatedKey
+}
+
+type rotatedKey struct {
+	id        interface{}
+	updatedAt time.Time
+	encrypted []byte
+}
+
+// PrepareChangePassword decrypts every encrypted P2P key and OCR key bundle,
+// including soft deleted bundles, with oldPassword and re-encrypts them in
+// memory under newPassword, without writing anything. A key that fails to
+// decrypt returns an error naming its ID.
+func (orm *ORM) PrepareChangePassword(oldPassword, newPassword string, p ...utils.ScryptParams) (*RotationPlan, error) {
+	var p2pKeys []p2pkey.EncryptedP2PKey
+	if err := orm.DB.Find(&p2pKeys).Error; err != nil {
+		return nil, errors.Wrap(err, "while loading P2P keys")
+	}
+	var ocrKeys []ocrkey.EncryptedKeyBundle
+	if err := orm.DB.Unscoped().Find(&ocrKeys).Error; err != nil {
+		return nil, errors.Wrap(err, "while loading OCR key bundles")
+	}
+
+	plan := &RotationPlan{}
+	for _, k := range p2pKeys {
+		key, err := k.Decrypt(oldPassword)
+		if err != nil {
+			return nil, errors.Wrapf(err, "while decrypting P2P key %d", k.ID)
+		}
+		encrypted, err := key.ToEncryptedP2PKey(newPassword, p...)
+		if err != nil {
+			return nil, errors.Wrapf(err, "while encrypting P2P key %d", k.ID)
+		}
+		plan.p2pKeys = append(plan.p2pKeys, rotatedKey{k.ID, k.UpdatedAt, encrypted.EncryptedPrivKey})
+	}
+	for _, k := range ocrKeys {
+		key, err := k.Decrypt(oldPassword)
+		if err != nil {
+			return nil, errors.Wrapf(err, "while decrypting OCR key bundle %s", k.ID)
+		}
+		encrypted, err := key.Encrypt(newPassword, p...)
+		if err != nil {
+			return nil, errors.Wrapf(err, "while encrypting OCR key bundle %s", k.ID)
+		}
+		plan.ocrKeyBundles = append(plan.ocrKeyBundles, rotatedKey{k.ID, k.UpdatedAt, encrypted.EncryptedPrivateKeys})
+	}
+	return plan, nil
+}
+
+// CommitChangePassword saves the keys re-encrypted by PrepareChangePassword
+// in a single transaction. If any key was added, removed or updated since the
+// plan was prepared, nothing is saved and ErrOptimisticUpdateConflict is
+// returned.
+func (orm *ORM) CommitChangePassword(plan *RotationPlan) error {
+	return orm.Transaction(func(tx *gorm.DB) error {
+		var p2pCount, ocrCount int
+		if err := tx.Model(&p2pkey.EncryptedP2PKey{}).Count(&p2pCount).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Model(&ocrkey.EncryptedKeyBundle{}).Count(&ocrCount).Error; err != nil {
+			return err
+		}
+		if p2pCount != len(plan.p2pKeys) || ocrCount != len(plan.ocrKeyBundles) {
+			return ErrOptimisticUpdateConflict
+		}
+
+		for _, k := range plan.p2pKeys {
+			err := commitRotatedKey(tx, &p2pkey.EncryptedP2PKey{}, "encrypted_priv_key", k)
+			if err != nil {
+				return errors.Wrapf(err, "while saving P2P key %v", k.id)
+			}
+		}
+		for _, k := range plan.ocrKeyBundles {
+			err := commitRotatedKey(tx.Unscoped(), &ocrkey.EncryptedKeyBundle{}, "encrypted_private_keys", k)
+			if err != nil {
+				return errors.Wrapf(err, "while saving OCR key bundle %v", k.id)
+			}
+		}
+		return nil
+	})
+}
+
+func commitRotatedKey(tx *gorm.DB, model interface{}, column string, k rotatedKey) error {
+	result := tx.Model(model).
+		Where("id = ? AND updated_at = ?", k.id, k.updatedAt).
+		Update(column, k.encrypted)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrOptimisticUpdateConflict
+	}
+	return nil
+}
+
+// ChangeP2PAndOCRKeysPassword re-encrypts every encrypted P2P key and OCR key
+// bundle, including soft deleted bundles, under newPassword. Every key is
+// decrypted with oldPassword before anything is written, and the update runs
+// in a single transaction, so either every key is re-encrypted or none is.
+func (orm *ORM) ChangeP2PAndOCRKeysPassword(oldPassword, newPassword string, p ...utils.ScryptParams) error {
+	plan, err := orm.PrepareChangePassword(oldPassword, newPassword, p...)
+	if err != nil {
+		return err
+	}
+	return orm.CommitChangePassword(plan)
+}
+
+// GenerateEncryptedP2PKeyFromSeed derives a P2P key from a 32 byte seed,
+// encrypts it under password and upserts it. The same seed always yields the
+// same peer ID, so a backed up seed can recover a node's identity.
+func (orm *ORM) GenerateEncryptedP2PKeyFromSeed(seed []byte, password string, p ...utils.ScryptParams) (
+	p2pkey.Key, p2pkey.EncryptedP2PKey, error,
+) {
+	key, err := p2pkey.CreateKeyFromSeed(seed)
+	if err != nil {
+		return p2pkey.Key{}, p2pkey.EncryptedP2PKey{}, err
+	}
+	encryptedKey, err := key.ToEncryptedP2PKey(password, p...)
+	if err != nil {
+		return p2pkey.Key{}, p2pkey.EncryptedP2PKey{}, errors.Wrap(err, "while encrypting P2P key")
+	}
+	if err := orm.UpsertEncryptedP2PKey(&encryptedKey); err != nil {
+		return p2pkey.Key{}, p2pkey.EncryptedP2PKey{}, err
+	}
+	return key, encryptedKey, nil
+}
+
+// ReplaceP2PKeyPassword re-encrypts the P2P key with the given ID under
+// newPassword, leaving every other key untouched. Keys are normally unlocked
+// with a single password, so this is only meant for interim migrations:
+// afterwards the keys can be encrypted under differing passwords.
+func (orm *ORM) ReplaceP2PKeyPassword(id int32, oldPassword, newPassword string, p ...utils.ScryptParams) error {
+	encryptedKey, err := orm.FindEncryptedP2PKeyByID(id)
+	if err != nil {
+		return errors.Wrapf(err, "while finding P2P key %d", id)
+	}
+	key, err := encryptedKey.Decrypt(oldPassword)
+	if err != nil {
+		return errors.Wrapf(err, "while decrypting P2P key %d", id)
+	}
+	reencryptedKey, err := key.ToEncryptedP2PKey(newPassword, p...)
+	if err != nil {
+		return errors.Wrapf(err, "while encrypting P2P key %d", id)
+	}
+	return orm.UpsertEncryptedP2PKey(&reencryptedKey)
 }
 
 func (orm *ORM) FindEncryptedP2PKeys() (keys []p2pkey.EncryptedP2PKey, err error) {
@@ -1321,13 +1528,209 @@ func (orm *ORM) FindEncryptedP2PKeyByID(id int32) (*p2pkey.EncryptedP2PKey, erro
 	return &key, nil
 }
 
+// FindEncryptedP2PKeyByPeerID finds the encrypted P2P key with the given peer
+// ID, returning gorm.ErrRecordNotFound if there is none
+func (orm *ORM) FindEncryptedP2PKeyByPeerID(peerID string) (*p2pkey.EncryptedP2PKey, error) {
+	key := p2pkey.EncryptedP2PKey{}
+	err := orm.DB.Where("peer_id = ?", peerID).First(&key).Error
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// P2PKeyExists reports whether an encrypted P2P key with the given peer ID exists
+func (orm *ORM) P2PKeyExists(peerID string) (bool, error) {
+	var count int
+	err := orm.DB.Model(&p2pkey.EncryptedP2PKey{}).Where("peer_id = ?", peerID).Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// GenerateP2PKeyIfNone creates a new P2P key encrypted under password if there
+// are no P2P keys yet, reporting whether a key was created. It is a no-op when
+// any P2P key already exists. The table is locked while checking, so
+// concurrent callers create at most one key between them.
+func (orm *ORM) GenerateP2PKeyIfNone(password string, p ...utils.ScryptParams) (p2pkey.EncryptedP2PKey, bool, error) {
+	var encryptedKey p2pkey.EncryptedP2PKey
+	var created bool
+	err := orm.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("LOCK TABLE encrypted_p2p_keys IN EXCLUSIVE MODE").Error; err != nil {
+			return errors.Wrap(err, "while locking P2P keys")
+		}
+		var count int
+		if err := tx.Model(&p2pkey.EncryptedP2PKey{}).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return nil
+		}
+
+		key, err := p2pkey.CreateKey()
+		if err != nil {
+			return errors.Wrap(err, "while generating P2P key")
+		}
+		encryptedKey, err = key.ToEncryptedP2PKey(password, p...)
+		if err != nil {
+			return errors.Wrap(err, "while encrypting P2P key")
+		}
+		if err := upsertEncryptedP2PKey(tx, &encryptedKey); err != nil {
+			return err
+		}
+		created = true
+		return nil
+	})
+	if err != nil || !created {
+		return p2pkey.EncryptedP2PKey{}, false, err
+	}
+	return encryptedKey, true, nil
+}
+
+// SetP2PKeyLabel sets the label of the encrypted P2P key with the given ID,
+// clearing it if label is empty. P2P key labels are unique, so ErrLabelTaken
+// is returned if another key already has label.
+func (orm *ORM) SetP2PKeyLabel(id int32, label string) error {
+	err := orm.setLabel(&p2pkey.EncryptedP2PKey{}, id, label)
+	return mapP2PKeyLabelError(err)
+}
+
+// mapP2PKeyLabelError turns a violation of the unique P2P key label index into
+// ErrLabelTaken
+func mapP2PKeyLabelError(err error) error {
+	if v, ok := err.(*pq.Error); ok && v.Constraint == "idx_unique_p2p_key_labels" {
+		return ErrLabelTaken
+	}
+	return err
+}
+
+// RenameP2PKeyLabel gives the encrypted P2P key with the given ID a new,
+// non-empty label, returning ErrLabelTaken if another key already has it
+func (orm *ORM) RenameP2PKeyLabel(id int32, newLabel string) error {
+	if newLabel == "" {
+		return errors.New("new label must not be empty")
+	}
+	return orm.SetP2PKeyLabel(id, newLabel)
+}
+
 func (orm *ORM) DeleteEncryptedP2PKey(key *p2pkey.EncryptedP2PKey) error {
 	return orm.DB.Delete(key).Error
 }
 
-// CreateEncryptedOCRKeyBundle creates an encrypted OCR private key record
+// CreateEncryptedOCRKeyBundle creates an encrypted OCR private key record. If
+// a soft deleted bundle with the same ID exists, it is restored with the given
+// encrypted private keys and label instead, and keys is reloaded from the
+// restored row.
 func (orm *ORM) CreateEncryptedOCRKeyBundle(keys *ocrkey.EncryptedKeyBundle) error {
-	return orm.DB.Create(keys).Error
+	return orm.Transaction(func(tx *gorm.DB) error {
+		return createEncryptedOCRKeyBundle(tx, keys)
+	})
+}
+
+func createEncryptedOCRKeyBundle(tx *gorm.DB, keys *ocrkey.EncryptedKeyBundle) error {
+	result := tx.Unscoped().
+		Model(&ocrkey.EncryptedKeyBundle{}).
+		Where("id = ? AND deleted_at IS NOT NULL", keys.ID).
+		Updates(map[string]interface{}{
+			"deleted_at":             nil,
+			"encrypted_private_keys": keys.EncryptedPrivateKeys,
+			"label":                  keys.Label,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected > 0 {
+		return tx.Where("id = ?", keys.ID).First(keys).Error
+	}
+	return tx.Create(keys).Error
+}
+
+// CreateEncryptedOCRKeyBundles creates the given encrypted OCR key bundles in
+// a single transaction, so that either all of them are persisted or none is
+func (orm *ORM) CreateEncryptedOCRKeyBundles(keys []*ocrkey.EncryptedKeyBundle) error {
+	return orm.Transaction(func(tx *gorm.DB) error {
+		for _, k := range keys {
+			if err := createEncryptedOCRKeyBundle(tx, k); err != nil {
+				return errors.Wrapf(err, "while creating OCR key bundle %s", k.ID)
+			}
+		}
+		return nil
+	})
+}
+
+// GenerateEncryptedOCRKeyBundles generates count new OCR key bundles,
+// encrypts them under password and persists them in a single transaction. If
+// any bundle fails to generate or persist, nothing is persisted and no
+// bundles are returned.
+func (orm *ORM) GenerateEncryptedOCRKeyBundles(password string, count int, p ...utils.ScryptParams) (
+	[]ocrkey.KeyBundle, []ocrkey.EncryptedKeyBundle, error,
+) {
+	if count <= 0 {
+		return nil, nil, fmt.Errorf("count must be positive, got %d", count)
+	}
+	keys := make([]ocrkey.KeyBundle, count)
+	encryptedKeys := make([]ocrkey.EncryptedKeyBundle, count)
+	toCreate := make([]*ocrkey.EncryptedKeyBundle, count)
+	for i := 0; i < count; i++ {
+		key, err := ocrkey.NewKeyBundle()
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "while generating OCR key bundle")
+		}
+		encryptedKey, err := key.Encrypt(password, p...)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "while encrypting OCR key bundle %s", key.ID)
+		}
+		keys[i] = *key
+		encryptedKeys[i] = *encryptedKey
+		toCreate[i] = &encryptedKeys[i]
+	}
+	if err := orm.CreateEncryptedOCRKeyBundles(toCreate); err != nil {
+		return nil, nil, err
+	}
+	return keys, encryptedKeys, nil
+}
+
+// GenerateEncryptedOCRKeyBundleFromMnemonic derives an OCR key bundle from a
+// BIP-39 mnemonic, encrypts it under password and persists it. The same
+// mnemonic always yields the same bundle ID, so a soft deleted bundle derived
+// from it is restored rather than duplicated.
+func (orm *ORM) GenerateEncryptedOCRKeyBundleFromMnemonic(mnemonic string, password string, p ...utils.ScryptParams) (
+	ocrkey.KeyBundle, ocrkey.EncryptedKeyBundle, error,
+) {
+	key, err := ocrkey.NewKeyBundleFromMnemonic(mnemonic)
+	if err != nil {
+		return ocrkey.KeyBundle{}, ocrkey.EncryptedKeyBundle{}, err
+	}
+	encryptedKey, err := key.Encrypt(password, p...)
+	if err != nil {
+		return ocrkey.KeyBundle{}, ocrkey.EncryptedKeyBundle{}, errors.Wrapf(err, "while encrypting OCR key bundle %s", key.ID)
+	}
+	if err := orm.CreateEncryptedOCRKeyBundle(encryptedKey); err != nil {
+		return ocrkey.KeyBundle{}, ocrkey.EncryptedKeyBundle{}, errors.Wrapf(err, "while creating OCR key bundle %s", key.ID)
+	}
+	return *key, *encryptedKey, nil
+}
+
+// RekeyOCRKeyBundle generates and persists a new OCR key bundle to replace
+// the bundle with the given ID, returning the new bundle so that job specs can
+// be repointed at its ID. The old bundle must decrypt with password, so that
+// both share a password, and is kept usable until it is explicitly deleted.
+func (orm *ORM) RekeyOCRKeyBundle(oldID string, password string, p ...utils.ScryptParams) (
+	ocrkey.KeyBundle, ocrkey.EncryptedKeyBundle, error,
+) {
+	old, err := orm.FindEncryptedOCRKeyBundleByID(oldID)
+	if err != nil {
+		return ocrkey.KeyBundle{}, ocrkey.EncryptedKeyBundle{}, errors.Wrapf(err, "while finding OCR key bundle %s", oldID)
+	}
+	if _, err = old.Decrypt(password); err != nil {
+		return ocrkey.KeyBundle{}, ocrkey.EncryptedKeyBundle{}, errors.Wrapf(err, "while decrypting OCR key bundle %s", oldID)
+	}
+	keys, encryptedKeys, err := orm.GenerateEncryptedOCRKeyBundles(password, 1, p...)
+	if err != nil {
+		return ocrkey.KeyBundle{}, ocrkey.EncryptedKeyBundle{}, err
+	}
+	return keys[0], encryptedKeys[0], nil
 }
 
 // FindEncryptedOCRKeyBundles finds all the encrypted OCR key records
@@ -1339,6 +1742,17 @@ func (orm *ORM) FindEncryptedOCRKeyBundles() (keys []ocrkey.EncryptedKeyBundle,
 	return keys, nil
 }
 
+// FindEncryptedOCRKeyBundlesByLabel finds all the encrypted OCR key records
+// with the given label
+func (orm *ORM) FindEncryptedOCRKeyBundlesByLabel(label string) (keys []ocrkey.EncryptedKeyBundle, err error) {
+	keys = []ocrkey.EncryptedKeyBundle{}
+	err = orm.DB.Where("label = ?", label).Find(&keys).Error
+	if err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
 // FindEncryptedOCRKeyBundleByID finds an EncryptedKeyBundle bundle by it's ID
 func (orm *ORM) FindEncryptedOCRKeyBundleByID(id string) (*ocrkey.EncryptedKeyBundle, error) {
 	key := ocrkey.EncryptedKeyBundle{}
@@ -1349,11 +1763,138 @@ func (orm *ORM) FindEncryptedOCRKeyBundleByID(id string) (*ocrkey.EncryptedKeyBu
 	return &key, nil
 }
 
-// DeleteEncryptedOCRKeyBundle deletes the provided encrypted OCR key bundle
+// OCRKeyBundleExists reports whether an encrypted OCR key bundle with the
+// given ID exists, ignoring soft deleted bundles
+func (orm *ORM) OCRKeyBundleExists(id string) (bool, error) {
+	var count int
+	err := orm.DB.Model(&ocrkey.EncryptedKeyBundle{}).Where("id = ?", id).Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// SetOCRKeyBundleLabel sets the label of the encrypted OCR key bundle with the
+// given ID, clearing it if label is empty
+func (orm *ORM) SetOCRKeyBundleLabel(id string, label string) error {
+	return orm.setLabel(&ocrkey.EncryptedKeyBundle{}, id, label)
+}
+
+// setLabel updates the label column of the key model with the given ID,
+// returning gorm.ErrRecordNotFound if there is no such key
+func (orm *ORM) setLabel(model interface{}, id interface{}, label string) error {
+	result := orm.DB.Model(model).
+		Where("id = ?", id).
+		Update("label", null.NewString(label, label != ""))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// DeleteEncryptedOCRKeyBundle soft deletes the provided encrypted OCR key bundle
 func (orm *ORM) DeleteEncryptedOCRKeyBundle(key *ocrkey.EncryptedKeyBundle) (err error) {
 	return orm.DB.Delete(key).Error
 }
 
+// PurgeEncryptedOCRKeyBundle permanently removes the provided encrypted OCR
+// key bundle, whether or not it has been soft deleted
+func (orm *ORM) PurgeEncryptedOCRKeyBundle(key *ocrkey.EncryptedKeyBundle) error {
+	return orm.DB.Unscoped().Delete(key).Error
+}
+
+// FindDeletedOCRKeyBundles finds all the soft deleted encrypted OCR key records
+func (orm *ORM) FindDeletedOCRKeyBundles() (keys []ocrkey.EncryptedKeyBundle, err error) {
+	err = orm.DB.Unscoped().Where("deleted_at IS NOT NULL").Find(&keys).Error
+	if err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// RestoreEncryptedOCRKeyBundle undoes the soft delete of the OCR key bundle
+// with the given ID, returning gorm.ErrRecordNotFound if there is no such
+// deleted bundle
+func (orm *ORM) RestoreEncryptedOCRKeyBundle(id string) error {
+	result := orm.DB.Unscoped().
+		Model(&ocrkey.EncryptedKeyBundle{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// PurgeDeletedOCRKeyBundles permanently removes OCR key bundles that were soft
+// deleted more than olderThan ago
+func (orm *ORM) PurgeDeletedOCRKeyBundles(olderThan time.Duration) error {
+	return orm.DB.Unscoped().
+		Where("deleted_at < ?", time.Now().Add(-olderThan)).
+		Delete(&ocrkey.EncryptedKeyBundle{}).Error
+}
+
+// DeleteAllKeysConfirmation must be passed to DeleteAllP2PKeys and
+// DeleteAllOCRKeyBundles to confirm the deletion
+const DeleteAllKeysConfirmation = "DELETE ALL KEYS"
+
+// DeleteAllP2PKeys permanently removes every encrypted P2P key, returning the
+// number of keys removed. confirm must equal DeleteAllKeysConfirmation.
+func (orm *ORM) DeleteAllP2PKeys(confirm string) (int64, error) {
+	if confirm != DeleteAllKeysConfirmation {
+		return 0, ErrWrongConfirmation
+	}
+	var deleted int64
+	err := orm.Transaction(func(tx *gorm.DB) error {
+		result := tx.Delete(&p2pkey.EncryptedP2PKey{})
+		deleted = result.RowsAffected
+		return result.Error
+	})
+	if err != nil {
+		return 0, err
+	}
+	return deleted, nil
+}
+
+// DeleteAllOCRKeyBundles permanently removes every encrypted OCR key bundle,
+// including soft deleted ones, returning the number of bundles removed.
+// confirm must equal DeleteAllKeysConfirmation.
+func (orm *ORM) DeleteAllOCRKeyBundles(confirm string) (int64, error) {
+	if confirm != DeleteAllKeysConfirmation {
+		return 0, ErrWrongConfirmation
+	}
+	var deleted int64
+	err := orm.Transaction(func(tx *gorm.DB) error {
+		result := tx.Unscoped().Delete(&ocrkey.EncryptedKeyBundle{})
+		deleted = result.RowsAffected
+		return result.Error
+	})
+	if err != nil {
+		return 0, err
+	}
+	return deleted, nil
+}
+
+// CountP2PAndOCRKeys returns the number of encrypted P2P keys and OCR key
+// bundles, excluding soft deleted bundles
+func (orm *ORM) CountP2PAndOCRKeys() (p2p int, ocr int, err error) {
+	err = orm.DB.Model(&p2pkey.EncryptedP2PKey{}).Count(&p2p).Error
+	if err != nil {
+		return 0, 0, err
+	}
+	err = orm.DB.Model(&ocrkey.EncryptedKeyBundle{}).Count(&ocr).Error
+	if err != nil {
+		return 0, 0, err
+	}
+	return p2p, ocr, nil
+}
+
 // GetRoundRobinAddress queries the database for the address of a random ethereum key derived from the id.
 // This takes an optional param for a slice of addresses it should pick from. Leave empty to pick from all
 // addresses in the database.
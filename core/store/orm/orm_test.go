@@ -1,6 +1,7 @@
 package orm_test
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"math/big"
@@ -17,12 +18,15 @@ import (
 	"github.com/smartcontractkit/chainlink/core/services"
 	"github.com/smartcontractkit/chainlink/core/services/synchronization"
 	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/store/models/ocrkey"
+	"github.com/smartcontractkit/chainlink/core/store/models/p2pkey"
 	"github.com/smartcontractkit/chainlink/core/store/orm"
 	"github.com/smartcontractkit/chainlink/core/utils"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"gopkg.in/guregu/null.v3"
@@ -2114,3 +2118,768 @@ func TestORM_GetRoundRobinAddress(t *testing.T) {
 		require.Equal(t, "no keys available", err.Error())
 	})
 }
+
+func TestORM_ChangeP2PAndOCRKeysPassword(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	p2pKey, err := p2pkey.CreateKey()
+	require.NoError(t, err)
+	encryptedP2PKey, err := p2pKey.ToEncryptedP2PKey(cltest.Password, utils.FastScryptParams)
+	require.NoError(t, err)
+	require.NoError(t, store.UpsertEncryptedP2PKey(&encryptedP2PKey))
+	ocrKey, err := ocrkey.NewKeyBundle()
+	require.NoError(t, err)
+	encryptedOCRKey, err := ocrKey.Encrypt(cltest.Password, utils.FastScryptParams)
+	require.NoError(t, err)
+	require.NoError(t, store.CreateEncryptedOCRKeyBundle(encryptedOCRKey))
+
+	require.NoError(t, store.ChangeP2PAndOCRKeysPassword(cltest.Password, "new password", utils.FastScryptParams))
+
+	p2pKeys, err := store.FindEncryptedP2PKeys()
+	require.NoError(t, err)
+	require.Len(t, p2pKeys, 1)
+	_, err = p2pKeys[0].Decrypt("new password")
+	require.NoError(t, err)
+	_, err = p2pKeys[0].Decrypt(cltest.Password)
+	require.Error(t, err)
+
+	ocrKeys, err := store.FindEncryptedOCRKeyBundles()
+	require.NoError(t, err)
+	require.Len(t, ocrKeys, 1)
+	_, err = ocrKeys[0].Decrypt("new password")
+	require.NoError(t, err)
+	_, err = ocrKeys[0].Decrypt(cltest.Password)
+	require.Error(t, err)
+}
+
+func TestORM_ChangeP2PAndOCRKeysPassword_WrongPassword(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	p2pKey, err := p2pkey.CreateKey()
+	require.NoError(t, err)
+	encryptedP2PKey, err := p2pKey.ToEncryptedP2PKey(cltest.Password, utils.FastScryptParams)
+	require.NoError(t, err)
+	require.NoError(t, store.UpsertEncryptedP2PKey(&encryptedP2PKey))
+	ocrKey, err := ocrkey.NewKeyBundle()
+	require.NoError(t, err)
+	encryptedOCRKey, err := ocrKey.Encrypt("other password", utils.FastScryptParams)
+	require.NoError(t, err)
+	require.NoError(t, store.CreateEncryptedOCRKeyBundle(encryptedOCRKey))
+
+	err = store.ChangeP2PAndOCRKeysPassword(cltest.Password, "new password", utils.FastScryptParams)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), encryptedOCRKey.ID)
+
+	found, err := store.FindEncryptedP2PKeyByID(encryptedP2PKey.ID)
+	require.NoError(t, err)
+	assert.Equal(t, encryptedP2PKey.EncryptedPrivKey, found.EncryptedPrivKey)
+}
+
+func TestORM_EncryptedOCRKeyBundles_SoftDelete(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	key := cltest.MustInsertOffchainreportingKeyBundle(t, store)
+	require.NoError(t, store.DeleteEncryptedOCRKeyBundle(key))
+
+	keys, err := store.FindEncryptedOCRKeyBundles()
+	require.NoError(t, err)
+	assert.Len(t, keys, 0)
+	_, err = store.FindEncryptedOCRKeyBundleByID(key.ID)
+	assert.True(t, gorm.IsRecordNotFoundError(err))
+
+	deleted, err := store.FindDeletedOCRKeyBundles()
+	require.NoError(t, err)
+	require.Len(t, deleted, 1)
+	assert.Equal(t, key.ID, deleted[0].ID)
+
+	require.NoError(t, store.RestoreEncryptedOCRKeyBundle(key.ID))
+	keys, err = store.FindEncryptedOCRKeyBundles()
+	require.NoError(t, err)
+	assert.Len(t, keys, 1)
+
+	err = store.RestoreEncryptedOCRKeyBundle(key.ID)
+	assert.True(t, gorm.IsRecordNotFoundError(err))
+}
+
+func TestORM_PurgeDeletedOCRKeyBundles(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	key := cltest.MustInsertOffchainreportingKeyBundle(t, store)
+	require.NoError(t, store.DeleteEncryptedOCRKeyBundle(key))
+
+	require.NoError(t, store.PurgeDeletedOCRKeyBundles(time.Hour))
+	deleted, err := store.FindDeletedOCRKeyBundles()
+	require.NoError(t, err)
+	require.Len(t, deleted, 1)
+
+	live := cltest.MustInsertOffchainreportingKeyBundle(t, store)
+
+	require.NoError(t, store.PurgeDeletedOCRKeyBundles(0))
+	deleted, err = store.FindDeletedOCRKeyBundles()
+	require.NoError(t, err)
+	require.Len(t, deleted, 0)
+
+	keys, err := store.FindEncryptedOCRKeyBundles()
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.Equal(t, live.ID, keys[0].ID)
+}
+
+func TestORM_PurgeEncryptedOCRKeyBundle(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	live := cltest.MustInsertOffchainreportingKeyBundle(t, store)
+	archived := cltest.MustInsertOffchainreportingKeyBundle(t, store)
+	purged := cltest.MustInsertOffchainreportingKeyBundle(t, store)
+	require.NoError(t, store.DeleteEncryptedOCRKeyBundle(archived))
+
+	require.NoError(t, store.PurgeEncryptedOCRKeyBundle(purged))
+	require.NoError(t, store.PurgeEncryptedOCRKeyBundle(archived))
+
+	keys, err := store.FindEncryptedOCRKeyBundles()
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.Equal(t, live.ID, keys[0].ID)
+
+	deleted, err := store.FindDeletedOCRKeyBundles()
+	require.NoError(t, err)
+	assert.Len(t, deleted, 0)
+}
+
+func TestORM_CreateEncryptedOCRKeyBundle_RestoresSoftDeleted(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	key := cltest.MustInsertOffchainreportingKeyBundle(t, store)
+	require.NoError(t, store.DeleteEncryptedOCRKeyBundle(key))
+
+	recreated := ocrkey.EncryptedKeyBundle{
+		ID:                    key.ID,
+		OnChainSigningAddress: key.OnChainSigningAddress,
+		OffChainPublicKey:     key.OffChainPublicKey,
+		EncryptedPrivateKeys:  key.EncryptedPrivateKeys,
+		Label:                 null.StringFrom("restored"),
+	}
+	require.NoError(t, store.CreateEncryptedOCRKeyBundle(&recreated))
+	assert.False(t, recreated.DeletedAt.Valid)
+	assert.Equal(t, null.StringFrom("restored"), recreated.Label)
+
+	keys, err := store.FindEncryptedOCRKeyBundles()
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.Equal(t, key.ID, keys[0].ID)
+
+	deleted, err := store.FindDeletedOCRKeyBundles()
+	require.NoError(t, err)
+	assert.Len(t, deleted, 0)
+}
+
+func TestORM_ChangeP2PAndOCRKeysPassword_SoftDeleted(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	key := cltest.MustInsertOffchainreportingKeyBundle(t, store)
+	require.NoError(t, store.DeleteEncryptedOCRKeyBundle(key))
+
+	require.NoError(t, store.ChangeP2PAndOCRKeysPassword(cltest.Password, "new password", utils.FastScryptParams))
+
+	deleted, err := store.FindDeletedOCRKeyBundles()
+	require.NoError(t, err)
+	require.Len(t, deleted, 1)
+	_, err = deleted[0].Decrypt("new password")
+	require.NoError(t, err)
+}
+
+func TestORM_GenerateEncryptedP2PKeyFromSeed(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	seed := bytes.Repeat([]byte{0x42}, 32)
+	key, encryptedKey, err := store.GenerateEncryptedP2PKeyFromSeed(seed, cltest.Password, utils.FastScryptParams)
+	require.NoError(t, err)
+	peerID, err := key.GetPeerID()
+	require.NoError(t, err)
+	assert.Equal(t, "12D3KooWC4T1AXU2s2YBgGJ2FeaYVtsKoHZWJeubnWe9SnuSE7Zb", peerID.Pretty())
+	assert.Equal(t, peerID.Pretty(), encryptedKey.PeerID)
+
+	keys, err := store.FindEncryptedP2PKeys()
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.Equal(t, encryptedKey.PeerID, keys[0].PeerID)
+
+	_, _, err = store.GenerateEncryptedP2PKeyFromSeed(seed[:16], cltest.Password, utils.FastScryptParams)
+	assert.EqualError(t, err, "p2p key seed must be 32 bytes, got 16")
+}
+
+func TestORM_GenerateEncryptedOCRKeyBundles(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	keys, encryptedKeys, err := store.GenerateEncryptedOCRKeyBundles(cltest.Password, 2, utils.FastScryptParams)
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+	require.Len(t, encryptedKeys, 2)
+
+	found, err := store.FindEncryptedOCRKeyBundles()
+	require.NoError(t, err)
+	require.Len(t, found, 2)
+	for i, k := range encryptedKeys {
+		assert.Equal(t, keys[i].ID, k.ID)
+		assert.Equal(t, keys[i].PublicKeyAddressOnChain(), k.OnChainSigningAddress)
+	}
+
+	for _, count := range []int{0, -1} {
+		_, _, err = store.GenerateEncryptedOCRKeyBundles(cltest.Password, count, utils.FastScryptParams)
+		assert.Error(t, err)
+	}
+}
+
+func TestORM_GenerateEncryptedOCRKeyBundles_RollsBack(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	// fail the second insert of the batch, after the first one has been written
+	require.NoError(t, store.DB.Exec(`
+CREATE FUNCTION fail_second_ocr_key_bundle() RETURNS trigger AS $$
+BEGIN
+	IF (SELECT COUNT(*) FROM encrypted_ocr_key_bundles) > 0 THEN
+		RAISE EXCEPTION 'injected failure';
+	END IF;
+	RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+CREATE TRIGGER fail_second_ocr_key_bundle BEFORE INSERT ON encrypted_ocr_key_bundles
+FOR EACH ROW EXECUTE PROCEDURE fail_second_ocr_key_bundle();
+`).Error)
+
+	keys, encryptedKeys, err := store.GenerateEncryptedOCRKeyBundles(cltest.Password, 2, utils.FastScryptParams)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "injected failure")
+	assert.Nil(t, keys)
+	assert.Nil(t, encryptedKeys)
+
+	found, err := store.FindEncryptedOCRKeyBundles()
+	require.NoError(t, err)
+	assert.Len(t, found, 0)
+}
+
+func TestORM_CreateEncryptedOCRKeyBundles_RollsBack(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	newEncryptedKey := func() *ocrkey.EncryptedKeyBundle {
+		key, err := ocrkey.NewKeyBundle()
+		require.NoError(t, err)
+		encryptedKey, err := key.Encrypt(cltest.Password, utils.FastScryptParams)
+		require.NoError(t, err)
+		return encryptedKey
+	}
+	a := newEncryptedKey()
+	b := newEncryptedKey()
+	duplicateA := *a
+
+	// the third bundle reuses a's ID, so the batch fails after two inserts
+	err := store.CreateEncryptedOCRKeyBundles([]*ocrkey.EncryptedKeyBundle{a, b, &duplicateA})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), a.ID)
+
+	found, err := store.FindEncryptedOCRKeyBundles()
+	require.NoError(t, err)
+	assert.Len(t, found, 0)
+
+	require.NoError(t, store.CreateEncryptedOCRKeyBundles([]*ocrkey.EncryptedKeyBundle{a, b}))
+	found, err = store.FindEncryptedOCRKeyBundles()
+	require.NoError(t, err)
+	assert.Len(t, found, 2)
+}
+
+func TestORM_FindEncryptedP2PKeyByPeerID(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	key := cltest.MustInsertP2PKey(t, store)
+
+	found, err := store.FindEncryptedP2PKeyByPeerID(key.PeerID)
+	require.NoError(t, err)
+	assert.Equal(t, key.ID, found.ID)
+	assert.Equal(t, key.PubKey, found.PubKey)
+
+	_, err = store.FindEncryptedP2PKeyByPeerID("12D3KooWNotARealPeerID")
+	assert.True(t, gorm.IsRecordNotFoundError(err))
+}
+
+func TestORM_VerifyP2PAndOCRKeysPassword(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	assert.Equal(t, orm.ErrNoKeysToVerify, store.VerifyP2PAndOCRKeysPassword(cltest.Password))
+
+	cltest.MustInsertP2PKey(t, store)
+	assert.NoError(t, store.VerifyP2PAndOCRKeysPassword(cltest.Password))
+	assert.Equal(t, orm.ErrWrongPassword, store.VerifyP2PAndOCRKeysPassword("wrong password"))
+
+	cltest.MustInsertOffchainreportingKeyBundle(t, store)
+	assert.NoError(t, store.VerifyP2PAndOCRKeysPassword(cltest.Password))
+	assert.Equal(t, orm.ErrWrongPassword, store.VerifyP2PAndOCRKeysPassword("wrong password"))
+}
+
+func TestORM_SetP2PKeyLabel(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	key := cltest.MustInsertP2PKey(t, store)
+
+	require.NoError(t, store.SetP2PKeyLabel(key.ID, "mainnet-ETHUSD"))
+	found, err := store.FindEncryptedP2PKeyByID(key.ID)
+	require.NoError(t, err)
+	assert.Equal(t, null.StringFrom("mainnet-ETHUSD"), found.Label)
+
+	require.NoError(t, store.SetP2PKeyLabel(key.ID, "mainnet-BTCUSD"))
+	found, err = store.FindEncryptedP2PKeyByID(key.ID)
+	require.NoError(t, err)
+	assert.Equal(t, null.StringFrom("mainnet-BTCUSD"), found.Label)
+	assert.Equal(t, key.PeerID, found.PeerID)
+
+	require.NoError(t, store.SetP2PKeyLabel(key.ID, ""))
+	found, err = store.FindEncryptedP2PKeyByID(key.ID)
+	require.NoError(t, err)
+	assert.False(t, found.Label.Valid)
+
+	err = store.SetP2PKeyLabel(key.ID+1, "missing")
+	assert.True(t, gorm.IsRecordNotFoundError(err))
+}
+
+func TestORM_SetOCRKeyBundleLabel(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	key := cltest.MustInsertOffchainreportingKeyBundle(t, store)
+
+	require.NoError(t, store.SetOCRKeyBundleLabel(key.ID, "mainnet-ETHUSD"))
+	found, err := store.FindEncryptedOCRKeyBundleByID(key.ID)
+	require.NoError(t, err)
+	assert.Equal(t, null.StringFrom("mainnet-ETHUSD"), found.Label)
+
+	require.NoError(t, store.SetOCRKeyBundleLabel(key.ID, "mainnet-BTCUSD"))
+	found, err = store.FindEncryptedOCRKeyBundleByID(key.ID)
+	require.NoError(t, err)
+	assert.Equal(t, null.StringFrom("mainnet-BTCUSD"), found.Label)
+	assert.Equal(t, key.ID, found.ID)
+
+	require.NoError(t, store.SetOCRKeyBundleLabel(key.ID, ""))
+	found, err = store.FindEncryptedOCRKeyBundleByID(key.ID)
+	require.NoError(t, err)
+	assert.False(t, found.Label.Valid)
+
+	err = store.SetOCRKeyBundleLabel("deadbeef", "missing")
+	assert.True(t, gorm.IsRecordNotFoundError(err))
+}
+
+func TestORM_RekeyOCRKeyBundle(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	old := cltest.MustInsertOffchainreportingKeyBundle(t, store)
+
+	key, encryptedKey, err := store.RekeyOCRKeyBundle(old.ID, cltest.Password, utils.FastScryptParams)
+	require.NoError(t, err)
+	assert.NotEqual(t, old.ID, key.ID)
+	assert.Equal(t, key.ID, encryptedKey.ID)
+
+	keys, err := store.FindEncryptedOCRKeyBundles()
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+
+	// the old bundle stays usable until it is deleted
+	found, err := store.FindEncryptedOCRKeyBundleByID(old.ID)
+	require.NoError(t, err)
+	_, err = found.Decrypt(cltest.Password)
+	require.NoError(t, err)
+
+	_, _, err = store.RekeyOCRKeyBundle(old.ID, "wrong password", utils.FastScryptParams)
+	require.Error(t, err)
+	_, _, err = store.RekeyOCRKeyBundle("missing", cltest.Password, utils.FastScryptParams)
+	require.Error(t, err)
+	keys, err = store.FindEncryptedOCRKeyBundles()
+	require.NoError(t, err)
+	assert.Len(t, keys, 2)
+}
+
+func TestORM_P2PKeyExists(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	key := cltest.MustInsertP2PKey(t, store)
+
+	exists, err := store.P2PKeyExists(key.PeerID)
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	exists, err = store.P2PKeyExists("12D3KooWNotARealPeerID")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestORM_OCRKeyBundleExists(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	key := cltest.MustInsertOffchainreportingKeyBundle(t, store)
+
+	exists, err := store.OCRKeyBundleExists(key.ID)
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	require.NoError(t, store.DeleteEncryptedOCRKeyBundle(key))
+	exists, err = store.OCRKeyBundleExists(key.ID)
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestORM_ReplaceP2PKeyPassword(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	target := cltest.MustInsertP2PKey(t, store)
+	other := cltest.MustInsertP2PKey(t, store)
+
+	require.NoError(t, store.ReplaceP2PKeyPassword(target.ID, cltest.Password, "new password", utils.FastScryptParams))
+
+	found, err := store.FindEncryptedP2PKeyByID(target.ID)
+	require.NoError(t, err)
+	_, err = found.Decrypt("new password")
+	require.NoError(t, err)
+	_, err = found.Decrypt(cltest.Password)
+	require.Error(t, err)
+
+	found, err = store.FindEncryptedP2PKeyByID(other.ID)
+	require.NoError(t, err)
+	assert.Equal(t, other.EncryptedPrivKey, found.EncryptedPrivKey)
+
+	keys, err := store.FindEncryptedP2PKeys()
+	require.NoError(t, err)
+	assert.Len(t, keys, 2)
+
+	err = store.ReplaceP2PKeyPassword(target.ID, cltest.Password, "newer password", utils.FastScryptParams)
+	require.Error(t, err)
+}
+
+func TestORM_GenerateEncryptedOCRKeyBundleFromMnemonic(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	key, encryptedKey, err := store.GenerateEncryptedOCRKeyBundleFromMnemonic(mnemonic, cltest.Password, utils.FastScryptParams)
+	require.NoError(t, err)
+	assert.Equal(t, "ec2c63b5ecef3c88db11595fc9d6b3a2ec18ea1226e4680b6e380f4be6d5f411", key.ID)
+	assert.Equal(t, key.ID, encryptedKey.ID)
+
+	// deriving again after a soft delete restores the same bundle
+	require.NoError(t, store.DeleteEncryptedOCRKeyBundle(&encryptedKey))
+	key, _, err = store.GenerateEncryptedOCRKeyBundleFromMnemonic(mnemonic, cltest.Password, utils.FastScryptParams)
+	require.NoError(t, err)
+	assert.Equal(t, "ec2c63b5ecef3c88db11595fc9d6b3a2ec18ea1226e4680b6e380f4be6d5f411", key.ID)
+
+	keys, err := store.FindEncryptedOCRKeyBundles()
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.Equal(t, key.ID, keys[0].ID)
+
+	_, _, err = store.GenerateEncryptedOCRKeyBundleFromMnemonic("not a mnemonic", cltest.Password, utils.FastScryptParams)
+	require.Error(t, err)
+}
+
+func TestORM_PrepareAndCommitChangePassword(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	p2pKey := cltest.MustInsertP2PKey(t, store)
+	cltest.MustInsertOffchainreportingKeyBundle(t, store)
+
+	plan, err := store.PrepareChangePassword(cltest.Password, "new password", utils.FastScryptParams)
+	require.NoError(t, err)
+
+	// nothing is written until the plan is committed
+	found, err := store.FindEncryptedP2PKeyByID(p2pKey.ID)
+	require.NoError(t, err)
+	assert.Equal(t, p2pKey.EncryptedPrivKey, found.EncryptedPrivKey)
+
+	require.NoError(t, store.CommitChangePassword(plan))
+	require.NoError(t, store.VerifyP2PAndOCRKeysPassword("new password"))
+	found, err = store.FindEncryptedP2PKeyByID(p2pKey.ID)
+	require.NoError(t, err)
+	_, err = found.Decrypt("new password")
+	require.NoError(t, err)
+
+	// the plan no longer matches the keys it was prepared from
+	assert.Equal(t, orm.ErrOptimisticUpdateConflict, errors.Cause(store.CommitChangePassword(plan)))
+}
+
+func TestORM_PrepareChangePassword_UndecryptableKey(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	p2pKey := cltest.MustInsertP2PKey(t, store)
+	bundle, err := ocrkey.NewKeyBundle()
+	require.NoError(t, err)
+	encryptedBundle, err := bundle.Encrypt("other password", utils.FastScryptParams)
+	require.NoError(t, err)
+	require.NoError(t, store.CreateEncryptedOCRKeyBundle(encryptedBundle))
+
+	plan, err := store.PrepareChangePassword(cltest.Password, "new password", utils.FastScryptParams)
+	require.Error(t, err)
+	assert.Nil(t, plan)
+	assert.Contains(t, err.Error(), encryptedBundle.ID)
+
+	found, err := store.FindEncryptedP2PKeyByID(p2pKey.ID)
+	require.NoError(t, err)
+	assert.Equal(t, p2pKey.EncryptedPrivKey, found.EncryptedPrivKey)
+}
+
+func TestORM_CommitChangePassword_KeyAdded(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	p2pKey := cltest.MustInsertP2PKey(t, store)
+	plan, err := store.PrepareChangePassword(cltest.Password, "new password", utils.FastScryptParams)
+	require.NoError(t, err)
+
+	cltest.MustInsertOffchainreportingKeyBundle(t, store)
+	assert.Equal(t, orm.ErrOptimisticUpdateConflict, store.CommitChangePassword(plan))
+
+	found, err := store.FindEncryptedP2PKeyByID(p2pKey.ID)
+	require.NoError(t, err)
+	assert.Equal(t, p2pKey.EncryptedPrivKey, found.EncryptedPrivKey)
+}
+
+func TestORM_GenerateP2PKeyIfNone(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty store", func(t *testing.T) {
+		store, cleanup := cltest.NewStore(t)
+		defer cleanup()
+
+		key, created, err := store.GenerateP2PKeyIfNone(cltest.Password, utils.FastScryptParams)
+		require.NoError(t, err)
+		assert.True(t, created)
+		_, err = key.Decrypt(cltest.Password)
+		require.NoError(t, err)
+
+		keys, err := store.FindEncryptedP2PKeys()
+		require.NoError(t, err)
+		require.Len(t, keys, 1)
+		assert.Equal(t, key.PeerID, keys[0].PeerID)
+	})
+
+	t.Run("non-empty store", func(t *testing.T) {
+		store, cleanup := cltest.NewStore(t)
+		defer cleanup()
+
+		existing := cltest.MustInsertP2PKey(t, store)
+
+		_, created, err := store.GenerateP2PKeyIfNone(cltest.Password, utils.FastScryptParams)
+		require.NoError(t, err)
+		assert.False(t, created)
+
+		keys, err := store.FindEncryptedP2PKeys()
+		require.NoError(t, err)
+		require.Len(t, keys, 1)
+		assert.Equal(t, existing.PeerID, keys[0].PeerID)
+	})
+}
+
+func TestORM_DeleteAllP2PAndOCRKeys(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	cltest.MustInsertP2PKey(t, store)
+	cltest.MustInsertP2PKey(t, store)
+	cltest.MustInsertOffchainreportingKeyBundle(t, store)
+	deleted := cltest.MustInsertOffchainreportingKeyBundle(t, store)
+	require.NoError(t, store.DeleteEncryptedOCRKeyBundle(deleted))
+
+	_, err := store.DeleteAllP2PKeys("delete all keys")
+	assert.Equal(t, orm.ErrWrongConfirmation, err)
+	_, err = store.DeleteAllOCRKeyBundles("")
+	assert.Equal(t, orm.ErrWrongConfirmation, err)
+	p2pKeys, err := store.FindEncryptedP2PKeys()
+	require.NoError(t, err)
+	assert.Len(t, p2pKeys, 2)
+	ocrKeys, err := store.FindEncryptedOCRKeyBundles()
+	require.NoError(t, err)
+	assert.Len(t, ocrKeys, 1)
+
+	count, err := store.DeleteAllP2PKeys(orm.DeleteAllKeysConfirmation)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+	count, err = store.DeleteAllOCRKeyBundles(orm.DeleteAllKeysConfirmation)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	p2pKeys, err = store.FindEncryptedP2PKeys()
+	require.NoError(t, err)
+	assert.Len(t, p2pKeys, 0)
+	ocrKeys, err = store.FindEncryptedOCRKeyBundles()
+	require.NoError(t, err)
+	assert.Len(t, ocrKeys, 0)
+	archived, err := store.FindDeletedOCRKeyBundles()
+	require.NoError(t, err)
+	assert.Len(t, archived, 0)
+}
+
+func TestORM_FindEncryptedOCRKeyBundlesByLabel(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	mainnet1 := cltest.MustInsertOffchainreportingKeyBundle(t, store)
+	mainnet2 := cltest.MustInsertOffchainreportingKeyBundle(t, store)
+	kovan := cltest.MustInsertOffchainreportingKeyBundle(t, store)
+	cltest.MustInsertOffchainreportingKeyBundle(t, store)
+	require.NoError(t, store.SetOCRKeyBundleLabel(mainnet1.ID, "mainnet"))
+	require.NoError(t, store.SetOCRKeyBundleLabel(mainnet2.ID, "mainnet"))
+	require.NoError(t, store.SetOCRKeyBundleLabel(kovan.ID, "kovan"))
+
+	keys, err := store.FindEncryptedOCRKeyBundlesByLabel("mainnet")
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+	assert.ElementsMatch(t, []string{mainnet1.ID, mainnet2.ID}, []string{keys[0].ID, keys[1].ID})
+
+	keys, err = store.FindEncryptedOCRKeyBundlesByLabel("kovan")
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.Equal(t, kovan.ID, keys[0].ID)
+
+	keys, err = store.FindEncryptedOCRKeyBundlesByLabel("rinkeby")
+	require.NoError(t, err)
+	assert.NotNil(t, keys)
+	assert.Len(t, keys, 0)
+}
+
+func TestORM_RenameP2PKeyLabel(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	key := cltest.MustInsertP2PKey(t, store)
+	other := cltest.MustInsertP2PKey(t, store)
+	require.NoError(t, store.SetP2PKeyLabel(other.ID, "kovan"))
+
+	require.NoError(t, store.RenameP2PKeyLabel(key.ID, "mainnet"))
+	found, err := store.FindEncryptedP2PKeyByID(key.ID)
+	require.NoError(t, err)
+	assert.Equal(t, null.StringFrom("mainnet"), found.Label)
+
+	err = store.RenameP2PKeyLabel(key.ID, "kovan")
+	assert.Equal(t, orm.ErrLabelTaken, err)
+	found, err = store.FindEncryptedP2PKeyByID(key.ID)
+	require.NoError(t, err)
+	assert.Equal(t, null.StringFrom("mainnet"), found.Label)
+
+	assert.Error(t, store.RenameP2PKeyLabel(key.ID, ""))
+
+	// unlabelled keys don't conflict with each other
+	require.NoError(t, store.SetP2PKeyLabel(key.ID, ""))
+	require.NoError(t, store.SetP2PKeyLabel(other.ID, ""))
+}
+
+func TestORM_UpsertEncryptedP2PKey_LabelTaken(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	existing := cltest.MustInsertP2PKey(t, store)
+	require.NoError(t, store.SetP2PKeyLabel(existing.ID, "mainnet"))
+
+	key, err := p2pkey.CreateKey()
+	require.NoError(t, err)
+	encryptedKey, err := key.ToEncryptedP2PKey(cltest.Password, utils.FastScryptParams)
+	require.NoError(t, err)
+	encryptedKey.Label = null.StringFrom("mainnet")
+
+	err = store.UpsertEncryptedP2PKey(&encryptedKey)
+	assert.Equal(t, orm.ErrLabelTaken, err)
+
+	keys, err := store.FindEncryptedP2PKeys()
+	require.NoError(t, err)
+	assert.Len(t, keys, 1)
+}
+
+func TestORM_CountP2PAndOCRKeys(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	p2p, ocr, err := store.CountP2PAndOCRKeys()
+	require.NoError(t, err)
+	assert.Equal(t, 0, p2p)
+	assert.Equal(t, 0, ocr)
+
+	cltest.MustInsertP2PKey(t, store)
+	cltest.MustInsertOffchainreportingKeyBundle(t, store)
+	cltest.MustInsertOffchainreportingKeyBundle(t, store)
+	deleted := cltest.MustInsertOffchainreportingKeyBundle(t, store)
+	require.NoError(t, store.DeleteEncryptedOCRKeyBundle(deleted))
+
+	p2p, ocr, err = store.CountP2PAndOCRKeys()
+	require.NoError(t, err)
+	assert.Equal(t, 1, p2p)
+	assert.Equal(t, 2, ocr)
+}
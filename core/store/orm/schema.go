@@ -50,6 +50,7 @@ type ConfigSchema struct {
 	GasUpdaterTransactionPercentile  uint16          `env:"GAS_UPDATER_TRANSACTION_PERCENTILE" default:"60"`
 	GasUpdaterEnabled                bool            `env:"GAS_UPDATER_ENABLED" default:"true"`
 	JSONConsole                      bool            `env:"JSON_CONSOLE" default:"false"`
+	KeystoreSelfTest                 bool            `env:"KEYSTORE_SELF_TEST" default:"false"`
 	LinkContractAddress              string          `env:"LINK_CONTRACT_ADDRESS" default:"0x514910771AF9Ca656af840dff83E8264EcF986CA"`
 	ExplorerURL                      *url.URL        `env:"EXPLORER_URL"`
 	ExplorerAccessKey                string          `env:"EXPLORER_ACCESS_KEY"`
@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+)
+
+const (
+	// MinScryptN is the weakest scrypt N accepted for encrypting keys. It is
+	// go-ethereum's light scrypt cost, meant for low-power hardware.
+	MinScryptN = keystore.LightScryptN
+	// MaxScryptN bounds the memory needed to encrypt or decrypt a key at 1GB
+	MaxScryptN = 1 << 20
+)
+
+// ScryptParams holds the scrypt cost parameters used when encrypting keys.
+// The scrypt block size r is fixed at 8 by go-ethereum's keystore encryption.
+type ScryptParams struct{ N, P int }
+
+// DefaultScryptParams is the recommended scrypt cost for encrypting keys
+var DefaultScryptParams = ScryptParams{N: keystore.StandardScryptN, P: keystore.StandardScryptP}
+
+// FastScryptParams is the cheapest scrypt cost accepted for encrypting keys.
+// It suits tests and low-power hardware, but makes keys far cheaper to
+// brute-force than DefaultScryptParams does.
+var FastScryptParams = ScryptParams{N: MinScryptN, P: 1}
+
+// Validate returns an error if p is too weak to protect keys, or so expensive
+// that encrypting a key would exhaust memory
+func (p ScryptParams) Validate() error {
+	if p.N < MinScryptN || p.N > MaxScryptN || p.N&(p.N-1) != 0 {
+		return fmt.Errorf("scrypt N must be a power of two between %d and %d, got %d", MinScryptN, MaxScryptN, p.N)
+	}
+	if p.P < 1 {
+		return fmt.Errorf("scrypt P must be at least 1, got %d", p.P)
+	}
+	return nil
+}
+
+// GetScryptParams returns the single set of scrypt params in p, or
+// DefaultScryptParams if p is empty, after checking that they are within safe
+// bounds. It is meant for functions taking optional scrypt params as a
+// variadic argument.
+func GetScryptParams(p ...ScryptParams) (ScryptParams, error) {
+	switch len(p) {
+	case 0:
+		return DefaultScryptParams, nil
+	case 1:
+		if err := p[0].Validate(); err != nil {
+			return ScryptParams{}, err
+		}
+		return p[0], nil
+	default:
+		return ScryptParams{}, fmt.Errorf("can take at most one set of ScryptParams")
+	}
+}
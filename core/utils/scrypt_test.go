@@ -0,0 +1,44 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScryptParams_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, utils.DefaultScryptParams.Validate())
+	assert.NoError(t, utils.FastScryptParams.Validate())
+	assert.NoError(t, utils.ScryptParams{N: utils.MaxScryptN, P: 2}.Validate())
+
+	for _, p := range []utils.ScryptParams{
+		{N: 2, P: 1},
+		{N: utils.MinScryptN / 2, P: 1},
+		{N: utils.MaxScryptN * 2, P: 1},
+		{N: utils.MinScryptN + 1, P: 1},
+		{N: utils.MinScryptN, P: 0},
+	} {
+		assert.Error(t, p.Validate(), "%+v", p)
+	}
+}
+
+func TestGetScryptParams(t *testing.T) {
+	t.Parallel()
+
+	p, err := utils.GetScryptParams()
+	require.NoError(t, err)
+	assert.Equal(t, utils.DefaultScryptParams, p)
+
+	p, err = utils.GetScryptParams(utils.FastScryptParams)
+	require.NoError(t, err)
+	assert.Equal(t, utils.FastScryptParams, p)
+
+	_, err = utils.GetScryptParams(utils.ScryptParams{N: 2, P: 1})
+	assert.Error(t, err)
+	_, err = utils.GetScryptParams(utils.FastScryptParams, utils.DefaultScryptParams)
+	assert.Error(t, err)
+}